@@ -0,0 +1,133 @@
+// Package agent caches an age DB passphrase in a short-lived background
+// process, listening on a unix socket, so lcs only has to prompt for the
+// passphrase once per session rather than on every invocation.
+package agent
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// dialTimeout bounds how long Get/Lock wait for the agent to answer,
+// so a stuck or half-dead agent can't hang an ordinary lcs invocation.
+const dialTimeout = 200 * time.Millisecond
+
+// SocketPath returns the path of the agent's unix socket. When
+// $XDG_RUNTIME_DIR isn't set (minimal containers, non-systemd setups,
+// su'd shells) it falls back to a private, 0700 per-user directory under
+// os.TempDir() rather than handing the socket straight to a world-writable
+// /tmp, where any other local user could connect and read the cached
+// passphrase.
+func SocketPath() string {
+	dir := os.Getenv("XDG_RUNTIME_DIR")
+	if dir == "" {
+		dir = privateTempDir()
+	}
+	return filepath.Join(dir, "lcs-agent.sock")
+}
+
+// privateTempDir returns (creating it if needed) a 0700 directory scoped
+// to the current user, used as the XDG_RUNTIME_DIR fallback above.
+func privateTempDir() string {
+	dir := filepath.Join(os.TempDir(), fmt.Sprintf("lcs-%d", os.Getuid()))
+	os.MkdirAll(dir, 0700)
+	os.Chmod(dir, 0700) // tighten perms if the directory already existed
+	return dir
+}
+
+// Get asks a running agent for its cached passphrase. ok is false if no
+// agent is listening - nothing has been cached yet, or it already expired.
+func Get() (passphrase string, ok bool) {
+	conn, err := net.DialTimeout("unix", SocketPath(), dialTimeout)
+	if err != nil {
+		return "", false
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(dialTimeout))
+
+	fmt.Fprintln(conn, "get")
+	line, err := bufio.NewReader(conn).ReadString('\n')
+	if err != nil {
+		return "", false
+	}
+	return strings.TrimSuffix(line, "\n"), true
+}
+
+// Lock tells a running agent to forget its cached passphrase and exit. It
+// is not an error for no agent to be running.
+func Lock() error {
+	conn, err := net.DialTimeout("unix", SocketPath(), dialTimeout)
+	if err != nil {
+		return nil
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(dialTimeout))
+
+	fmt.Fprintln(conn, "quit")
+	_, err = bufio.NewReader(conn).ReadString('\n')
+	return err
+}
+
+// Serve runs the agent: it listens on the socket, answers "get" requests
+// with passphrase, and stops once ttl elapses or a "quit" request arrives.
+// It blocks until then, so callers run it in a detached child process
+// (see cmd's spawnAgent).
+func Serve(passphrase string, ttl time.Duration) error {
+	path := SocketPath()
+	os.Remove(path) // clear a stale socket left by a crashed previous agent
+
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return fmt.Errorf("starting agent: %w", err)
+	}
+	defer os.Remove(path)
+
+	// net.Listen creates the socket honoring umask, which on a permissive
+	// umask can leave it group/world-readable; lock it to the owner only
+	// so another local user can't connect and ask for the passphrase.
+	if err := os.Chmod(path, 0600); err != nil {
+		listener.Close()
+		return fmt.Errorf("securing agent socket: %w", err)
+	}
+
+	var stopOnce sync.Once
+	stop := func() { stopOnce.Do(func() { listener.Close() }) }
+
+	timer := time.AfterFunc(ttl, stop)
+	defer timer.Stop()
+
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			return nil // listener closed: ttl expired, or "quit" was handled
+		}
+		if handleConn(conn, passphrase) {
+			stop()
+		}
+	}
+}
+
+// handleConn answers a single request and reports whether it was a "quit".
+func handleConn(conn net.Conn, passphrase string) (quit bool) {
+	defer conn.Close()
+
+	line, err := bufio.NewReader(conn).ReadString('\n')
+	if err != nil {
+		return false
+	}
+
+	switch strings.TrimSpace(line) {
+	case "get":
+		fmt.Fprintln(conn, passphrase)
+	case "quit":
+		fmt.Fprintln(conn, "ok")
+		return true
+	}
+	return false
+}
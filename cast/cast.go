@@ -0,0 +1,120 @@
+// Package cast writes and replays terminal sessions in the asciinema v2
+// cast format, so a command recorded with "lcs run --record" can later be
+// replayed as a reproducible demo with "lcs replay".
+package cast
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+)
+
+// header is the first line of a v2 cast file.
+type header struct {
+	Version   int   `json:"version"`
+	Width     int   `json:"width"`
+	Height    int   `json:"height"`
+	Timestamp int64 `json:"timestamp"`
+}
+
+// Recorder appends "o" (output) events to a v2 cast file as they happen,
+// each timestamped relative to when the recording started.
+type Recorder struct {
+	f     *os.File
+	start time.Time
+}
+
+// NewRecorder creates path, writes the header line with the given
+// terminal size, and returns a Recorder ready to have the session's
+// output written to it.
+func NewRecorder(path string, width, height int) (*Recorder, error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("creating cast file: %w", err)
+	}
+
+	h := header{Version: 2, Width: width, Height: height, Timestamp: time.Now().Unix()}
+	line, err := json.Marshal(h)
+	if err != nil {
+		f.Close()
+		return nil, fmt.Errorf("encoding cast header: %w", err)
+	}
+	if _, err := fmt.Fprintln(f, string(line)); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("writing cast header: %w", err)
+	}
+
+	return &Recorder{f: f, start: time.Now()}, nil
+}
+
+// Write implements io.Writer, logging p as a single "o" event timestamped
+// relative to NewRecorder. It always reports p as fully written: a
+// recording failure shouldn't take down the command the user is actually
+// running.
+func (r *Recorder) Write(p []byte) (int, error) {
+	event := []interface{}{time.Since(r.start).Seconds(), "o", string(p)}
+	if line, err := json.Marshal(event); err == nil {
+		fmt.Fprintln(r.f, string(line))
+	}
+	return len(p), nil
+}
+
+// Close closes the underlying cast file.
+func (r *Recorder) Close() error {
+	return r.f.Close()
+}
+
+// Replay reads a v2 cast file at path and writes its "o" events to
+// stdout, sleeping between them to reproduce the original timing.
+func Replay(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("opening cast file: %w", err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	if !scanner.Scan() {
+		return fmt.Errorf("empty cast file")
+	}
+	var h header
+	if err := json.Unmarshal(scanner.Bytes(), &h); err != nil {
+		return fmt.Errorf("parsing cast header: %w", err)
+	}
+
+	last := 0.0
+	for scanner.Scan() {
+		var event [3]json.RawMessage
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			return fmt.Errorf("parsing cast event: %w", err)
+		}
+
+		var elapsed float64
+		if err := json.Unmarshal(event[0], &elapsed); err != nil {
+			return fmt.Errorf("parsing cast event timestamp: %w", err)
+		}
+		var kind string
+		if err := json.Unmarshal(event[1], &kind); err != nil {
+			return fmt.Errorf("parsing cast event type: %w", err)
+		}
+		if kind != "o" {
+			continue
+		}
+		var data string
+		if err := json.Unmarshal(event[2], &data); err != nil {
+			return fmt.Errorf("parsing cast event data: %w", err)
+		}
+
+		if wait := elapsed - last; wait > 0 {
+			time.Sleep(time.Duration(wait * float64(time.Second)))
+		}
+		last = elapsed
+
+		fmt.Fprint(os.Stdout, data)
+	}
+	return scanner.Err()
+}
@@ -0,0 +1,62 @@
+// Package clipboard copies text to the system clipboard. The default
+// backend is github.com/atotto/clipboard, which covers macOS, Windows, and
+// X11 on Linux without any external tools. It doesn't speak Wayland, so on
+// Linux we first probe for wl-copy and only fall back to atotto/clipboard
+// (e.g. for an XWayland session) if that isn't available.
+package clipboard
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	atclipboard "github.com/atotto/clipboard"
+)
+
+// backend is the minimal clipboard write operation, split out so Copy can
+// be exercised against a fake without touching the real clipboard.
+type backend interface {
+	WriteAll(string) error
+}
+
+// activeBackend is the backend Copy writes through; swappable in tests.
+var activeBackend backend = compoundBackend{}
+
+// Copy places text on the system clipboard, picking the right backend for
+// the current platform and display server.
+func Copy(text string) error {
+	if err := activeBackend.WriteAll(text); err != nil {
+		return fmt.Errorf("copying to clipboard: %w", err)
+	}
+	return nil
+}
+
+// compoundBackend prefers wl-copy under Wayland and otherwise defers to
+// atotto/clipboard, which covers macOS, Windows, and X11.
+type compoundBackend struct{}
+
+func (compoundBackend) WriteAll(text string) error {
+	if os.Getenv("WAYLAND_DISPLAY") != "" {
+		if err := wlCopy(text); err == nil {
+			return nil
+		}
+		// Fall through: some Wayland sessions still run an X11 clipboard
+		// via XWayland, so give atotto/clipboard a chance too.
+	}
+	return atclipboard.WriteAll(text)
+}
+
+// wlCopy shells out to wl-copy, the clipboard tool shipped with
+// wl-clipboard, since neither Go nor atotto/clipboard talk to the Wayland
+// clipboard protocol directly.
+func wlCopy(text string) error {
+	path, err := exec.LookPath("wl-copy")
+	if err != nil {
+		return err
+	}
+
+	cmd := exec.Command(path)
+	cmd.Stdin = strings.NewReader(text)
+	return cmd.Run()
+}
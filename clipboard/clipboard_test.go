@@ -0,0 +1,112 @@
+package clipboard
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// fakeBackend is the backend double Copy is exercised against, as the
+// backend interface's doc describes.
+type fakeBackend struct {
+	got string
+	err error
+}
+
+func (f *fakeBackend) WriteAll(text string) error {
+	f.got = text
+	return f.err
+}
+
+// withBackend swaps activeBackend in for the duration of a test.
+func withBackend(t *testing.T, b backend) {
+	t.Helper()
+	orig := activeBackend
+	activeBackend = b
+	t.Cleanup(func() { activeBackend = orig })
+}
+
+func TestCopyWritesThroughActiveBackend(t *testing.T) {
+	fake := &fakeBackend{}
+	withBackend(t, fake)
+
+	if err := Copy("hello clipboard"); err != nil {
+		t.Fatalf("Copy returned error: %v", err)
+	}
+	if fake.got != "hello clipboard" {
+		t.Fatalf("backend got %q, want %q", fake.got, "hello clipboard")
+	}
+}
+
+func TestCopyWrapsBackendError(t *testing.T) {
+	withBackend(t, &fakeBackend{err: errors.New("boom")})
+
+	err := Copy("x")
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if !strings.Contains(err.Error(), "boom") {
+		t.Fatalf("error %q does not wrap the backend failure", err)
+	}
+}
+
+func TestWlCopyMissingBinary(t *testing.T) {
+	t.Setenv("PATH", t.TempDir())
+
+	if err := wlCopy("x"); err == nil {
+		t.Fatal("expected an error when wl-copy isn't on PATH")
+	}
+}
+
+// fakeWlCopy drops a "wl-copy" script on PATH that writes its stdin to a
+// file, so behavior that shells out to the real wl-clipboard tool can be
+// exercised without it installed.
+func fakeWlCopy(t *testing.T) (outFile string) {
+	t.Helper()
+	dir := t.TempDir()
+	outFile = filepath.Join(dir, "out")
+
+	script := "#!/bin/sh\ncat > " + outFile + "\n"
+	path := filepath.Join(dir, "wl-copy")
+	if err := os.WriteFile(path, []byte(script), 0755); err != nil {
+		t.Fatalf("writing fake wl-copy: %v", err)
+	}
+
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	return outFile
+}
+
+func TestWlCopyInvokesBinaryWithStdin(t *testing.T) {
+	outFile := fakeWlCopy(t)
+
+	if err := wlCopy("clip me"); err != nil {
+		t.Fatalf("wlCopy returned error: %v", err)
+	}
+
+	got, err := os.ReadFile(outFile)
+	if err != nil {
+		t.Fatalf("reading fake wl-copy output: %v", err)
+	}
+	if string(got) != "clip me" {
+		t.Fatalf("fake wl-copy received %q, want %q", got, "clip me")
+	}
+}
+
+func TestCompoundBackendPrefersWlCopyUnderWayland(t *testing.T) {
+	outFile := fakeWlCopy(t)
+	t.Setenv("WAYLAND_DISPLAY", "wayland-0")
+
+	if err := (compoundBackend{}).WriteAll("over wayland"); err != nil {
+		t.Fatalf("WriteAll returned error: %v", err)
+	}
+
+	got, err := os.ReadFile(outFile)
+	if err != nil {
+		t.Fatalf("reading fake wl-copy output: %v", err)
+	}
+	if string(got) != "over wayland" {
+		t.Fatalf("fake wl-copy received %q, want %q", got, "over wayland")
+	}
+}
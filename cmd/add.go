@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/Dorn8010/lcs/store"
+	"github.com/spf13/cobra"
+)
+
+var addCmd = &cobra.Command{
+	Use:   "add [description] [command]",
+	Short: "Add a new command to the DB",
+	Long: `Add stores a new Description;Command entry. Pass both as
+arguments, or omit them to be prompted interactively.`,
+	Args: cobra.ArbitraryArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		st, err := openStore(dbPathFlag)
+		if err != nil {
+			return err
+		}
+		defer st.Close()
+
+		var desc, cmdStr string
+		if len(args) >= 2 {
+			desc = args[0]
+			cmdStr = strings.Join(args[1:], " ")
+		} else {
+			reader := bufio.NewReader(os.Stdin)
+			fmt.Println("--- Add New Command ---")
+			fmt.Print("Description: ")
+			desc, _ = reader.ReadString('\n')
+			desc = strings.TrimSpace(desc)
+			fmt.Print("Command: ")
+			cmdStr, _ = reader.ReadString('\n')
+			cmdStr = strings.TrimSpace(cmdStr)
+		}
+
+		if desc == "" || cmdStr == "" {
+			return fmt.Errorf("description and command cannot be empty")
+		}
+
+		if err := st.Add(store.Entry{Description: desc, Command: cmdStr, CreatedAt: time.Now()}); err != nil {
+			return fmt.Errorf("writing to DB: %w", err)
+		}
+		fmt.Println("Entry added successfully.")
+		return nil
+	},
+}
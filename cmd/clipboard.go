@@ -0,0 +1,9 @@
+package cmd
+
+import "github.com/Dorn8010/lcs/clipboard"
+
+// copyToClipboard copies text to the system clipboard, working out of the
+// box on macOS, Windows, X11, and Wayland.
+func copyToClipboard(text string) error {
+	return clipboard.Copy(text)
+}
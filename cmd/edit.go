@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	editFast int
+	editTUI  bool
+)
+
+var editCmd = &cobra.Command{
+	Use:   "edit [term]",
+	Short: "Search, then edit a command in place",
+	Args:  cobra.ArbitraryArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		st, matches, err := loadAndMatch(args)
+		if err != nil {
+			if reportNoMatches(err) {
+				return nil
+			}
+			return err
+		}
+		defer st.Close()
+
+		reader := bufio.NewReader(os.Stdin)
+		entry, err := pickEntry(reader, matches, editFast, editTUI, verboseFlag, "Select command to EDIT:")
+		if err != nil {
+			return err
+		}
+
+		fmt.Println("\n--- Edit Entry (Press Enter to keep current) ---")
+
+		fmt.Printf("Description [%s]: ", entry.Description)
+		newDesc, _ := reader.ReadString('\n')
+		newDesc = strings.TrimSpace(newDesc)
+		if newDesc == "" {
+			newDesc = entry.Description
+		}
+
+		fmt.Printf("Command [%s]: ", entry.Command)
+		newCmd, _ := reader.ReadString('\n')
+		newCmd = strings.TrimSpace(newCmd)
+		if newCmd == "" {
+			newCmd = entry.Command
+		}
+
+		updated := entry.Entry
+		updated.Description = newDesc
+		updated.Command = newCmd
+		if err := st.Update(entry.Index, updated); err != nil {
+			return fmt.Errorf("saving DB: %w", err)
+		}
+		fmt.Println("Entry edited successfully.")
+		return nil
+	},
+}
+
+func init() {
+	editCmd.Flags().IntVarP(&editFast, "fast", "f", 0, "Fast selection of option number")
+	editCmd.Flags().BoolVar(&editTUI, "tui", false, "Force the interactive fuzzy selector")
+	editCmd.ValidArgsFunction = completeEntries
+}
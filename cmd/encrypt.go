@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"time"
+
+	"github.com/Dorn8010/lcs/agent"
+	"github.com/Dorn8010/lcs/store"
+	"golang.org/x/term"
+)
+
+// autoAgentTTL is how long an agent started implicitly (because a normal
+// command had to prompt for the passphrase) caches it. "lcs unlock" lets
+// the user ask for a longer-lived one explicitly.
+const autoAgentTTL = 15 * time.Minute
+
+// resolvePassphrase returns the passphrase for the age-encrypted DB at
+// path: from a running agent if one already has it cached, otherwise by
+// prompting once, verifying it against path (same as "lcs unlock" does),
+// and (best-effort) starting an agent so later lcs invocations in this
+// session don't have to prompt again.
+func resolvePassphrase(path string) (string, error) {
+	if passphrase, ok := agent.Get(); ok {
+		return passphrase, nil
+	}
+
+	fmt.Printf("Passphrase for %s: ", path)
+	raw, err := term.ReadPassword(int(os.Stdin.Fd()))
+	fmt.Println()
+	if err != nil {
+		return "", fmt.Errorf("reading passphrase: %w", err)
+	}
+	passphrase := string(raw)
+
+	st, err := store.OpenEncrypted(path, passphrase)
+	if err != nil {
+		return "", err
+	}
+	_, err = st.List()
+	st.Close()
+	if err != nil {
+		return "", fmt.Errorf("passphrase did not decrypt %s: %w", path, err)
+	}
+
+	if err := spawnAgent(passphrase, autoAgentTTL); err != nil && verboseFlag {
+		fmt.Fprintf(os.Stderr, "warning: could not start passphrase agent: %v\n", err)
+	}
+	return passphrase, nil
+}
+
+// spawnAgent starts the hidden "lcs __agent-serve" subcommand as a
+// detached background process and feeds it passphrase over a pipe rather
+// than argv, so it never shows up in a process listing.
+func spawnAgent(passphrase string, ttl time.Duration) error {
+	exe, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("locating lcs binary: %w", err)
+	}
+
+	child := exec.Command(exe, "__agent-serve", "--ttl", ttl.String())
+	stdin, err := child.StdinPipe()
+	if err != nil {
+		return err
+	}
+	if err := child.Start(); err != nil {
+		return fmt.Errorf("starting agent: %w", err)
+	}
+
+	fmt.Fprintln(stdin, passphrase)
+	stdin.Close()
+	go child.Wait() // detached: the agent outlives this process
+	return nil
+}
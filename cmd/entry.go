@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"fmt"
+	"os/user"
+	"path/filepath"
+	"strings"
+
+	"github.com/Dorn8010/lcs/store"
+)
+
+// appVersion is the lcs release version, surfaced by the cobra-generated
+// `lcs --version` flag.
+const appVersion = "0.92"
+
+// Entry pairs a store.Entry with its position in the last List() call, so
+// it can later be targeted by Store.Update/Delete.
+type Entry struct {
+	store.Entry
+	Index int
+}
+
+// resolveDBPath returns the effective DB path: the explicit override if
+// set, otherwise ~/.lcs-db.csv.
+func resolveDBPath(override string) (string, error) {
+	if override != "" {
+		return override, nil
+	}
+	usr, err := user.Current()
+	if err != nil {
+		return "", fmt.Errorf("getting user home: %w", err)
+	}
+	return filepath.Join(usr.HomeDir, ".lcs-db.csv"), nil
+}
+
+// openStore resolves the DB path and opens the backend selected by
+// --store (or auto-detected from the file extension). A ".age" DB (or
+// --store age) is opened via store.OpenEncrypted instead, with the
+// passphrase resolved by resolvePassphrase first.
+func openStore(dbPath string) (store.Store, error) {
+	path, err := resolveDBPath(dbPath)
+	if err != nil {
+		return nil, err
+	}
+
+	kind := storeKindFlag
+	if kind == "" {
+		kind = store.KindFromExt(path)
+	}
+	if strings.EqualFold(kind, "age") {
+		passphrase, err := resolvePassphrase(path)
+		if err != nil {
+			return nil, err
+		}
+		return store.OpenEncrypted(path, passphrase)
+	}
+
+	return store.Open(path, storeKindFlag)
+}
+
+// matchEntries filters entries against term (case-insensitive substring
+// match against description or command), tags each surviving entry with
+// its position in entries so it can later be edited or removed, and ranks
+// the result by frecency (most recent, most-used, and successful first)
+// rather than leaving it in insertion order.
+func matchEntries(entries []store.Entry, term string) []Entry {
+	term = strings.ToLower(term)
+	var matches []Entry
+	for idx, e := range entries {
+		if term == "" || strings.Contains(strings.ToLower(e.Description), term) || strings.Contains(strings.ToLower(e.Command), term) {
+			matches = append(matches, Entry{Entry: e, Index: idx})
+		}
+	}
+	sortByFrecency(matches)
+	return matches
+}
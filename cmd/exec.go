@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"io"
+	"os"
+	"os/exec"
+	"os/signal"
+	"syscall"
+
+	"github.com/Dorn8010/lcs/cast"
+	"github.com/creack/pty"
+	"golang.org/x/term"
+)
+
+// runShell executes finalCmd through bash on a pseudo-terminal, so
+// full-screen and interactive programs (vim, htop, ssh, less) render
+// correctly regardless of how lcs itself was invoked, and resizes the PTY
+// on SIGWINCH to track the real terminal. If recordPath is non-empty, the
+// session's output is also written there as an asciinema v2 cast for
+// later playback via "lcs replay".
+//
+// The caller decides what to do with the exit code (e.g. os.Exit, or
+// recording it to execution history first) rather than runShell exiting
+// the process itself.
+func runShell(finalCmd string, recordPath string) (exitCode int, err error) {
+	cmd := exec.Command("bash", "-c", finalCmd)
+
+	ptmx, err := pty.Start(cmd)
+	if err != nil {
+		return 1, err
+	}
+	defer ptmx.Close()
+
+	width, height := 80, 24
+	resize := func() {
+		if size, err := pty.GetsizeFull(os.Stdin); err == nil {
+			pty.Setsize(ptmx, size)
+			width, height = int(size.Cols), int(size.Rows)
+		}
+	}
+	resize()
+	winch := make(chan os.Signal, 1)
+	signal.Notify(winch, syscall.SIGWINCH)
+	defer signal.Stop(winch)
+	go func() {
+		for range winch {
+			resize()
+		}
+	}()
+
+	var out io.Writer = os.Stdout
+	if recordPath != "" {
+		rec, recErr := cast.NewRecorder(recordPath, width, height)
+		if recErr != nil {
+			cmd.Process.Kill()
+			cmd.Wait()
+			return 1, recErr
+		}
+		defer rec.Close()
+		out = io.MultiWriter(os.Stdout, rec)
+	}
+
+	oldState, stateErr := term.MakeRaw(int(os.Stdin.Fd()))
+	if stateErr == nil {
+		defer term.Restore(int(os.Stdin.Fd()), oldState)
+	}
+
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		for sig := range sigChan {
+			// Forward the signal to the child instead of swallowing it:
+			// unlike the old shared-TTY approach, the child now has its
+			// own controlling terminal (the PTY slave) and needs the
+			// signal delivered explicitly.
+			if cmd.Process != nil {
+				cmd.Process.Signal(sig)
+			}
+		}
+	}()
+	defer signal.Stop(sigChan)
+
+	go io.Copy(ptmx, os.Stdin)
+	io.Copy(out, ptmx)
+
+	runErr := cmd.Wait()
+	if runErr == nil {
+		return 0, nil
+	}
+	if exitError, ok := runErr.(*exec.ExitError); ok {
+		// SSH often returns non-zero on disconnects, which is fine; it's
+		// not a failure of lcs itself.
+		return exitError.ExitCode(), nil
+	}
+	return 1, runErr
+}
@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/Dorn8010/lcs/store"
+	"github.com/spf13/cobra"
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export <file>",
+	Short: "Export the DB to a file",
+	Long: `Export reads every entry from the configured DB and writes it to
+file, using the backend detected from file's extension (--store applies
+to the DB only, not to file).`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		src, err := openStore(dbPathFlag)
+		if err != nil {
+			return err
+		}
+		defer src.Close()
+
+		entries, err := src.List()
+		if err != nil {
+			return fmt.Errorf("opening DB: %w", err)
+		}
+
+		dst, err := store.Open(args[0], "")
+		if err != nil {
+			return err
+		}
+		defer dst.Close()
+
+		for _, e := range entries {
+			if err := dst.Add(e); err != nil {
+				return fmt.Errorf("writing export file: %w", err)
+			}
+		}
+		fmt.Printf("Exported %d entries to %s\n", len(entries), args[0])
+		return nil
+	},
+}
@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Dorn8010/lcs/store"
+	"github.com/spf13/cobra"
+)
+
+// errNoMatches is returned by loadAndMatch when the search term doesn't
+// match anything. Callers should treat it as a clean, zero-exit outcome
+// (see reportNoMatches) rather than a hard failure.
+var errNoMatches = errors.New("no matches found")
+
+// loadAndMatch opens the configured DB and filters its entries against the
+// search term built by joining args.
+func loadAndMatch(args []string) (st store.Store, matches []Entry, err error) {
+	st, err = openStore(dbPathFlag)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	entries, err := st.List()
+	if err != nil {
+		st.Close()
+		return nil, nil, fmt.Errorf("opening DB: %w\nPlease create the file with format: Description;Command", err)
+	}
+
+	matches = matchEntries(entries, joinArgs(args))
+	if len(matches) == 0 {
+		st.Close()
+		return nil, nil, errNoMatches
+	}
+	return st, matches, nil
+}
+
+// reportNoMatches prints the "no matches found" message and reports true
+// if err is errNoMatches, so a RunE can exit cleanly (status 0) instead of
+// treating an empty search as a hard error.
+func reportNoMatches(err error) bool {
+	if !errors.Is(err, errNoMatches) {
+		return false
+	}
+	fmt.Println("No matches found.")
+	return true
+}
+
+// joinArgs builds a single search term out of positional CLI args.
+func joinArgs(args []string) string {
+	return strings.Join(args, " ")
+}
+
+// completeEntries is a cobra ValidArgsFunction that tab-completes a
+// [term] argument against the configured store's entry descriptions,
+// rather than just the CLI's own subcommands and flags.
+func completeEntries(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	st, err := openStore(dbPathFlag)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	defer st.Close()
+
+	entries, err := st.List()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	var completions []string
+	for _, e := range entries {
+		if strings.HasPrefix(strings.ToLower(e.Description), strings.ToLower(toComplete)) {
+			completions = append(completions, e.Description)
+		}
+	}
+	return completions, cobra.ShellCompDirectiveNoFileComp
+}
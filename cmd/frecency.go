@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// Frecency tuning constants. These are the same kind of decay/boost knobs
+// most frecency implementations (shell history, browser address bars) use;
+// picked empirically rather than derived.
+const (
+	// frecencyLambda controls how fast use_count decays with age in the
+	// score = use_count * exp(-lambda * age_hours) formula. At this value
+	// a command not run in a week has lost about half its weight.
+	frecencyLambda = 0.004
+
+	// recencyWindow is how long after a successful run an entry gets the
+	// recencyBoost, so "the thing I just ran" outranks older, more-used
+	// entries even before use_count catches up.
+	recencyWindow = time.Hour
+	recencyBoost  = 2.0
+
+	// failureDemotion multiplies the score of an entry whose most recent
+	// execution failed, so a flaky command doesn't out-rank one that's
+	// never failed.
+	failureDemotion = 0.3
+)
+
+// frecencyScore ranks an entry by recent, successful use. Entries with no
+// recorded history (LastUsedAt is zero, as for the CSV/JSON backends or a
+// never-run sqlite entry) score 0 and fall back to their original List
+// order, since sortByFrecency is stable.
+func frecencyScore(e Entry, now time.Time) float64 {
+	if e.LastUsedAt.IsZero() {
+		return 0
+	}
+
+	age := now.Sub(e.LastUsedAt)
+	score := float64(e.UseCount) * math.Exp(-frecencyLambda*age.Hours())
+
+	if e.ExitCode != 0 {
+		score *= failureDemotion
+	} else if age < recencyWindow {
+		score += recencyBoost
+	}
+	return score
+}
+
+// sortByFrecency orders matches best-first by frecencyScore, preserving the
+// original (List) order among ties.
+func sortByFrecency(matches []Entry) {
+	now := time.Now()
+	sort.SliceStable(matches, func(i, j int) bool {
+		return frecencyScore(matches[i], now) > frecencyScore(matches[j], now)
+	})
+}
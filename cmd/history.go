@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Dorn8010/lcs/store"
+	"github.com/spf13/cobra"
+)
+
+var (
+	historyToday  bool
+	historyFailed bool
+	historyN      int
+)
+
+var historyCmd = &cobra.Command{
+	Use:   "history",
+	Short: "List recorded command executions",
+	Long: `History prints recorded executions (timestamp, exit status, and
+the fully expanded command that ran), newest first. Only the SQLite
+backend tracks execution history; other backends return an error.
+
+Use --today to only show executions from today, --failed to only show
+non-zero exits, and --history N to cap how many are shown.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		st, err := openStore(dbPathFlag)
+		if err != nil {
+			return err
+		}
+		defer st.Close()
+
+		hs, ok := st.(store.HistoryStore)
+		if !ok {
+			return fmt.Errorf("execution history requires the sqlite backend")
+		}
+
+		execs, err := hs.History(0)
+		if err != nil {
+			return fmt.Errorf("reading history: %w", err)
+		}
+
+		execs = filterExecutions(execs, historyToday, historyFailed)
+		if historyN > 0 && len(execs) > historyN {
+			execs = execs[:historyN]
+		}
+
+		for _, e := range execs {
+			status := "ok"
+			if e.ExitCode != 0 {
+				status = fmt.Sprintf("exit %d", e.ExitCode)
+			}
+			fmt.Printf("%s  [%s]  %s\n", e.Timestamp.Format("2006-01-02 15:04:05"), status, e.Command)
+		}
+		return nil
+	},
+}
+
+// filterExecutions narrows execs (already newest-first) down to those
+// matching the --today and --failed flags.
+func filterExecutions(execs []store.Execution, today, failedOnly bool) []store.Execution {
+	if !today && !failedOnly {
+		return execs
+	}
+
+	now := time.Now()
+	var out []store.Execution
+	for _, e := range execs {
+		if today && !isSameDay(e.Timestamp, now) {
+			continue
+		}
+		if failedOnly && e.ExitCode == 0 {
+			continue
+		}
+		out = append(out, e)
+	}
+	return out
+}
+
+func isSameDay(a, b time.Time) bool {
+	ay, am, ad := a.Date()
+	by, bm, bd := b.Date()
+	return ay == by && am == bm && ad == bd
+}
+
+func init() {
+	historyCmd.Flags().BoolVar(&historyToday, "today", false, "Only show executions from today")
+	historyCmd.Flags().BoolVar(&historyFailed, "failed", false, "Only show failed executions")
+	historyCmd.Flags().IntVar(&historyN, "history", 20, "Maximum number of executions to show (0 = all)")
+}
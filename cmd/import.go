@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Dorn8010/lcs/store"
+	"github.com/spf13/cobra"
+)
+
+var importCmd = &cobra.Command{
+	Use:   "import <file>",
+	Short: "Import entries from a file into the DB",
+	Long: `Import reads entries from file, using the backend detected from
+its extension, and appends them to the configured DB (--store applies to
+the DB only, not to file).`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		src, err := store.Open(args[0], "")
+		if err != nil {
+			return err
+		}
+		defer src.Close()
+
+		entries, err := src.List()
+		if err != nil {
+			return fmt.Errorf("opening import file (%s): %w", args[0], err)
+		}
+
+		dst, err := openStore(dbPathFlag)
+		if err != nil {
+			return err
+		}
+		defer dst.Close()
+
+		for _, e := range entries {
+			if e.CreatedAt.IsZero() {
+				e.CreatedAt = time.Now()
+			}
+			if err := dst.Add(e); err != nil {
+				return fmt.Errorf("writing to DB: %w", err)
+			}
+		}
+		fmt.Printf("Imported %d entries\n", len(entries))
+		return nil
+	},
+}
@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/Dorn8010/lcs/agent"
+	"github.com/Dorn8010/lcs/store"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+var unlockTTL time.Duration
+
+var unlockCmd = &cobra.Command{
+	Use:   "unlock",
+	Short: "Cache the DB passphrase in a background agent",
+	Long: `Unlock prompts once for the age passphrase, verifies it against
+--db, and starts a background agent that serves it over a unix socket at
+$XDG_RUNTIME_DIR/lcs-agent.sock, so later lcs invocations don't prompt
+again. The agent exits automatically after --ttl (default 1h), or
+immediately via "lcs lock".`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, err := resolveDBPath(dbPathFlag)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Passphrase for %s: ", path)
+		raw, err := term.ReadPassword(int(os.Stdin.Fd()))
+		fmt.Println()
+		if err != nil {
+			return fmt.Errorf("reading passphrase: %w", err)
+		}
+		passphrase := string(raw)
+
+		st, err := store.OpenEncrypted(path, passphrase)
+		if err != nil {
+			return err
+		}
+		_, err = st.List()
+		st.Close()
+		if err != nil {
+			return fmt.Errorf("passphrase did not decrypt %s: %w", path, err)
+		}
+
+		if err := spawnAgent(passphrase, unlockTTL); err != nil {
+			return err
+		}
+		fmt.Printf("Unlocked; cached until %s.\n", time.Now().Add(unlockTTL).Format(time.Kitchen))
+		return nil
+	},
+}
+
+var lockCmd = &cobra.Command{
+	Use:   "lock",
+	Short: "Forget the cached DB passphrase",
+	Long: `Lock tells a running agent (started by "lcs unlock", or
+automatically by a command that had to prompt) to forget its cached
+passphrase and exit. It's not an error if no agent is running.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := agent.Lock(); err != nil {
+			return fmt.Errorf("locking: %w", err)
+		}
+		fmt.Println("Locked.")
+		return nil
+	},
+}
+
+var agentServeTTL time.Duration
+
+// agentServeCmd is the hidden entry point spawnAgent re-execs into: it
+// reads the passphrase from its own stdin (never from argv, so it never
+// shows up in a process listing) and blocks serving it until --ttl
+// expires or "lcs lock" asks it to quit.
+var agentServeCmd = &cobra.Command{
+	Use:    "__agent-serve",
+	Hidden: true,
+	Args:   cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil {
+			return fmt.Errorf("reading passphrase from parent: %w", err)
+		}
+		return agent.Serve(strings.TrimSuffix(line, "\n"), agentServeTTL)
+	},
+}
+
+func init() {
+	unlockCmd.Flags().DurationVar(&unlockTTL, "ttl", time.Hour, "How long the agent caches the passphrase")
+	agentServeCmd.Flags().DurationVar(&agentServeTTL, "ttl", time.Hour, "How long to serve the passphrase before exiting")
+}
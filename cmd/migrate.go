@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Dorn8010/lcs/store"
+	"github.com/spf13/cobra"
+)
+
+var (
+	migrateFrom string
+	migrateTo   string
+)
+
+var migrateCmd = &cobra.Command{
+	Use:   "migrate --from <file> --to <file>",
+	Short: "Copy every entry from one store backend to another",
+	Long: `Migrate reads all entries from --from and writes them to --to,
+auto-detecting each backend from its file extension. Typical use is moving
+a plain CSV library onto SQLite to pick up frecency ranking and execution
+history:
+
+  lcs migrate --from ~/.lcs-db.csv --to ~/.lcs-db.sqlite`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if migrateFrom == "" || migrateTo == "" {
+			return fmt.Errorf("both --from and --to are required")
+		}
+
+		src, err := store.Open(migrateFrom, "")
+		if err != nil {
+			return fmt.Errorf("opening %s: %w", migrateFrom, err)
+		}
+		defer src.Close()
+
+		entries, err := src.List()
+		if err != nil {
+			return fmt.Errorf("reading %s: %w", migrateFrom, err)
+		}
+
+		dst, err := store.Open(migrateTo, "")
+		if err != nil {
+			return fmt.Errorf("opening %s: %w", migrateTo, err)
+		}
+		defer dst.Close()
+
+		for _, e := range entries {
+			if e.CreatedAt.IsZero() {
+				e.CreatedAt = time.Now()
+			}
+			if err := dst.Add(e); err != nil {
+				return fmt.Errorf("writing %s: %w", migrateTo, err)
+			}
+		}
+
+		fmt.Printf("Migrated %d entries from %s to %s\n", len(entries), migrateFrom, migrateTo)
+		return nil
+	},
+}
+
+func init() {
+	migrateCmd.Flags().StringVar(&migrateFrom, "from", "", "Source DB file")
+	migrateCmd.Flags().StringVar(&migrateTo, "to", "", "Destination DB file")
+}
@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+
+	"github.com/Dorn8010/lcs/params"
+)
+
+// substituteParams resolves cmdStr's placeholders, taking values from
+// paramFlags (repeated --param Label=value flags) first and falling back
+// to interactively prompting for anything paramFlags doesn't cover.
+func substituteParams(reader *bufio.Reader, cmdStr string, paramFlags []string) (string, error) {
+	overrides, err := parseParamFlags(paramFlags)
+	if err != nil {
+		return "", err
+	}
+	return params.Substitute(reader, cmdStr, overrides)
+}
+
+// parseParamFlags turns repeated --param Label=value flags into a lookup
+// map keyed by Label.
+func parseParamFlags(flags []string) (map[string]string, error) {
+	overrides := make(map[string]string, len(flags))
+	for _, f := range flags {
+		label, value, ok := strings.Cut(f, "=")
+		if !ok {
+			return nil, fmt.Errorf("--param %q: expected Label=value", f)
+		}
+		overrides[label] = value
+	}
+	return overrides, nil
+}
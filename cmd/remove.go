@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	removeFast int
+	removeTUI  bool
+)
+
+var removeCmd = &cobra.Command{
+	Use:   "remove [term]",
+	Short: "Search and remove a command from the DB",
+	Args:  cobra.ArbitraryArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		st, matches, err := loadAndMatch(args)
+		if err != nil {
+			if reportNoMatches(err) {
+				return nil
+			}
+			return err
+		}
+		defer st.Close()
+
+		reader := bufio.NewReader(os.Stdin)
+		entry, err := pickEntry(reader, matches, removeFast, removeTUI, verboseFlag, "Select command to REMOVE:")
+		if err != nil {
+			return err
+		}
+
+		if err := st.Delete(entry.Index); err != nil {
+			return fmt.Errorf("saving DB: %w", err)
+		}
+		fmt.Println("Entry removed successfully.")
+		return nil
+	},
+}
+
+func init() {
+	removeCmd.Flags().IntVarP(&removeFast, "fast", "f", 0, "Fast selection of option number")
+	removeCmd.Flags().BoolVar(&removeTUI, "tui", false, "Force the interactive fuzzy selector")
+	removeCmd.ValidArgsFunction = completeEntries
+}
@@ -0,0 +1,18 @@
+package cmd
+
+import (
+	"github.com/Dorn8010/lcs/cast"
+	"github.com/spf13/cobra"
+)
+
+var replayCmd = &cobra.Command{
+	Use:   "replay FILE",
+	Short: "Play back a recorded command",
+	Long: `Replay reads an asciinema v2 cast file written by "lcs run --record"
+and writes its output to the terminal with the original timing, so a
+saved command doubles as a reproducible demo.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return cast.Replay(args[0])
+	},
+}
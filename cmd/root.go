@@ -0,0 +1,71 @@
+// Package cmd wires up the lcs CLI's cobra subcommands.
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	dbPathFlag    string
+	storeKindFlag string
+	verboseFlag   bool
+)
+
+var rootCmd = &cobra.Command{
+	Use:     "lcs [search term]",
+	Version: appVersion,
+	Short:   "Library Command Search - store and find long commands easily",
+	Long: `Library Command Search tool for CLI commands.
+Store and find long commands easily.
+
+The DB contains an explanation and the command with optional variables.
+~/.lcs-db.csv is a ; separated CSV, e.g.:
+  Echo test;echo "Hello World"
+
+Calling lcs with no subcommand is shorthand for "lcs run": it searches,
+lets you pick a match, fills in any {"Label":"Default"} variables, and
+runs the result.`,
+	// Runtime conditions like an out-of-range --fast choice are ordinary
+	// RunE errors, not misuse of the CLI, so cobra's
+	// own error/usage printing is silenced in favor of Execute's single
+	// fmt.Println(err) path below.
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	Args:          cobra.ArbitraryArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runRun(cmd, args)
+	},
+}
+
+// Execute runs the root command, exiting the process non-zero on error.
+func Execute() {
+	if err := rootCmd.Execute(); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+}
+
+func init() {
+	rootCmd.PersistentFlags().StringVar(&dbPathFlag, "db", "", "Path to custom DB file (default: ~/.lcs-db.csv)")
+	rootCmd.PersistentFlags().StringVar(&storeKindFlag, "store", "", "Force a store backend: csv, json, or sqlite (default: detected from --db extension)")
+	rootCmd.PersistentFlags().BoolVarP(&verboseFlag, "verbose", "v", false, "Verbose output")
+
+	rootCmd.AddCommand(searchCmd)
+	rootCmd.AddCommand(runCmd)
+	rootCmd.AddCommand(addCmd)
+	rootCmd.AddCommand(editCmd)
+	rootCmd.AddCommand(removeCmd)
+	rootCmd.AddCommand(importCmd)
+	rootCmd.AddCommand(exportCmd)
+	rootCmd.AddCommand(migrateCmd)
+	rootCmd.AddCommand(completionCmd)
+	rootCmd.AddCommand(historyCmd)
+	rootCmd.AddCommand(statsCmd)
+	rootCmd.AddCommand(unlockCmd)
+	rootCmd.AddCommand(lockCmd)
+	rootCmd.AddCommand(agentServeCmd)
+	rootCmd.AddCommand(replayCmd)
+}
@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/Dorn8010/lcs/store"
+	"github.com/spf13/cobra"
+)
+
+var (
+	runFast   int
+	runTUI    bool
+	runPrint  bool
+	runCopy   bool
+	runParams []string
+	runRecord string
+)
+
+var runCmd = &cobra.Command{
+	Use:   "run [term]",
+	Short: "Search, select, and execute a command from the DB",
+	Long: `Run is the default lcs behavior: it searches, lets you pick a
+match, fills in any {"Label":"Default"} variables, and runs the resulting
+command through bash. Pass --print or --copy to print/copy it instead of
+running it, or --record FILE to save the session as an asciinema v2 cast
+that "lcs replay" can play back later.`,
+	Args: cobra.ArbitraryArgs,
+	RunE: runRun,
+}
+
+func runRun(cmd *cobra.Command, args []string) error {
+	st, matches, err := loadAndMatch(args)
+	if err != nil {
+		if reportNoMatches(err) {
+			return nil
+		}
+		return err
+	}
+	defer st.Close()
+
+	reader := bufio.NewReader(os.Stdin)
+	entry, err := pickEntry(reader, matches, runFast, runTUI, verboseFlag, "Found commands:")
+	if err != nil {
+		return err
+	}
+
+	finalCmd, err := substituteParams(reader, entry.Command, runParams)
+	if err != nil {
+		return err
+	}
+
+	if runPrint {
+		fmt.Println(finalCmd)
+		return nil
+	}
+
+	if runCopy {
+		if err := copyToClipboard(finalCmd); err != nil {
+			return fmt.Errorf("copying to clipboard: %w", err)
+		}
+		fmt.Println("Command copied to clipboard.")
+		return nil
+	}
+
+	if verboseFlag {
+		fmt.Println("\nExecuting:", finalCmd)
+	} else if len(matches) > 1 {
+		fmt.Println("\nExecuting...")
+	}
+
+	exitCode, err := runShell(finalCmd, runRecord)
+	recordExecution(st, entry, finalCmd, exitCode)
+	if err != nil {
+		return fmt.Errorf("execution error: %w", err)
+	}
+	if exitCode != 0 {
+		os.Exit(exitCode)
+	}
+	return nil
+}
+
+// recordExecution logs finalCmd's outcome against entry when st supports
+// execution history (currently only the SQLite backend does). Failures to
+// record are not fatal: losing a history entry shouldn't stop the command
+// the user just ran from having succeeded.
+func recordExecution(st store.Store, entry Entry, finalCmd string, exitCode int) {
+	hs, ok := st.(store.HistoryStore)
+	if !ok {
+		return
+	}
+
+	dir, err := os.Getwd()
+	if err != nil {
+		dir = ""
+	}
+
+	exec := store.Execution{
+		Command:   finalCmd,
+		ExitCode:  exitCode,
+		Timestamp: time.Now(),
+		Dir:       dir,
+	}
+	if err := hs.RecordExecution(entry.Index, exec); err != nil && verboseFlag {
+		fmt.Fprintf(os.Stderr, "warning: recording execution history: %v\n", err)
+	}
+}
+
+func init() {
+	runCmd.Flags().IntVarP(&runFast, "fast", "f", 0, "Fast selection of option number")
+	runCmd.Flags().BoolVar(&runTUI, "tui", false, "Force the interactive fuzzy selector")
+	runCmd.Flags().BoolVar(&runPrint, "print", false, "Print command only (don't execute)")
+	runCmd.Flags().BoolVar(&runCopy, "copy", false, "Copy command to clipboard (don't execute)")
+	runCmd.Flags().StringArrayVar(&runParams, "param", nil, "Supply a placeholder value non-interactively (Label=value, repeatable)")
+	runCmd.Flags().StringVar(&runRecord, "record", "", "Record the session to FILE as an asciinema v2 cast (see: lcs replay)")
+	runCmd.ValidArgsFunction = completeEntries
+
+	// The root command is shorthand for "run", so it needs the same flags.
+	rootCmd.Flags().IntVarP(&runFast, "fast", "f", 0, "Fast selection of option number")
+	rootCmd.Flags().BoolVar(&runTUI, "tui", false, "Force the interactive fuzzy selector")
+	rootCmd.Flags().BoolVar(&runPrint, "print", false, "Print command only (don't execute)")
+	rootCmd.Flags().BoolVar(&runCopy, "copy", false, "Copy command to clipboard (don't execute)")
+	rootCmd.Flags().StringArrayVar(&runParams, "param", nil, "Supply a placeholder value non-interactively (Label=value, repeatable)")
+	rootCmd.Flags().StringVar(&runRecord, "record", "", "Record the session to FILE as an asciinema v2 cast (see: lcs replay)")
+	rootCmd.ValidArgsFunction = completeEntries
+}
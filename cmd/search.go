@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	searchFast   int
+	searchTUI    bool
+	searchCopy   bool
+	searchParams []string
+)
+
+var searchCmd = &cobra.Command{
+	Use:   "search [term]",
+	Short: "Search the DB and print the matching command",
+	Long: `Search finds matching entries, lets you pick one, fills in any
+{"Label":"Default"} variables, and prints the resulting command without
+running it. Use "lcs run" to execute it instead.`,
+	Args: cobra.ArbitraryArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		st, matches, err := loadAndMatch(args)
+		if err != nil {
+			if reportNoMatches(err) {
+				return nil
+			}
+			return err
+		}
+		defer st.Close()
+
+		reader := bufio.NewReader(os.Stdin)
+		entry, err := pickEntry(reader, matches, searchFast, searchTUI, verboseFlag, "Found commands:")
+		if err != nil {
+			return err
+		}
+
+		finalCmd, err := substituteParams(reader, entry.Command, searchParams)
+		if err != nil {
+			return err
+		}
+
+		if searchCopy {
+			if err := copyToClipboard(finalCmd); err != nil {
+				return fmt.Errorf("copying to clipboard: %w", err)
+			}
+			fmt.Println("Command copied to clipboard.")
+			return nil
+		}
+
+		fmt.Println(finalCmd)
+		return nil
+	},
+}
+
+func init() {
+	searchCmd.Flags().IntVarP(&searchFast, "fast", "f", 0, "Fast selection of option number")
+	searchCmd.Flags().BoolVar(&searchTUI, "tui", false, "Force the interactive fuzzy selector")
+	searchCmd.Flags().BoolVar(&searchCopy, "copy", false, "Copy the resulting command to the clipboard")
+	searchCmd.Flags().StringArrayVar(&searchParams, "param", nil, "Supply a placeholder value non-interactively (Label=value, repeatable)")
+	searchCmd.ValidArgsFunction = completeEntries
+}
@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Dorn8010/lcs/selector"
+)
+
+// pickEntry narrows matches down to a single Entry. fastChoice, when > 0,
+// selects by position without prompting. Otherwise, it uses the
+// interactive fuzzy TUI when the terminal supports it (or tui is forced),
+// falling back to the original numbered prompt.
+func pickEntry(reader *bufio.Reader, matches []Entry, fastChoice int, tui, verbose bool, prompt string) (Entry, error) {
+	if fastChoice > 0 {
+		if fastChoice > len(matches) {
+			return Entry{}, fmt.Errorf("fast choice %d is out of range, only %d matches found", fastChoice, len(matches))
+		}
+		chosen := matches[fastChoice-1]
+		if verbose {
+			fmt.Printf("Fast selected [%d]: %s\n", fastChoice, chosen.Description)
+		}
+		return chosen, nil
+	}
+
+	if len(matches) == 1 {
+		fmt.Printf("Found 1 match: %s\n", matches[0].Description)
+		fmt.Printf("Cmd : %s\n", matches[0].Command)
+		return matches[0], nil
+	}
+
+	if tui || selector.IsSupported() {
+		selEntries := make([]selector.Entry, len(matches))
+		for i, m := range matches {
+			selEntries[i] = selector.Entry{Description: m.Description, Command: m.Command, Failed: m.ExitCode != 0, Index: i}
+		}
+
+		picked, err := selector.Select(selEntries)
+		if err != nil {
+			if err == selector.ErrCancelled {
+				return Entry{}, fmt.Errorf("selection cancelled")
+			}
+			return Entry{}, err
+		}
+
+		return matches[picked.Index], nil
+	}
+
+	fmt.Println(prompt)
+	for i, m := range matches {
+		desc := m.Description
+		if m.ExitCode != 0 {
+			desc = "\x1b[31m" + desc + "\x1b[0m"
+		}
+		fmt.Printf("[%d] %s \n    Cmd: %s\n", i+1, desc, m.Command)
+	}
+
+	fmt.Print("\nSelect a number: ")
+	inputStr, _ := reader.ReadString('\n')
+	inputStr = strings.TrimSpace(inputStr)
+
+	idx, err := strconv.Atoi(inputStr)
+	if err != nil || idx < 1 || idx > len(matches) {
+		return Entry{}, fmt.Errorf("invalid selection")
+	}
+	return matches[idx-1], nil
+}
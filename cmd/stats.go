@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/spf13/cobra"
+)
+
+var statsTop int
+
+var statsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Summarize the most-used entries",
+	Long: `Stats lists entries ranked by use_count, along with their last
+exit status, most-used first. Only the SQLite backend tracks use_count;
+other backends show every entry at 0.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		st, err := openStore(dbPathFlag)
+		if err != nil {
+			return err
+		}
+		defer st.Close()
+
+		entries, err := st.List()
+		if err != nil {
+			return fmt.Errorf("opening DB: %w", err)
+		}
+
+		sort.SliceStable(entries, func(i, j int) bool {
+			return entries[i].UseCount > entries[j].UseCount
+		})
+		if statsTop > 0 && len(entries) > statsTop {
+			entries = entries[:statsTop]
+		}
+
+		for _, e := range entries {
+			status := "ok"
+			if e.ExitCode != 0 {
+				status = fmt.Sprintf("exit %d", e.ExitCode)
+			}
+			fmt.Printf("%4d uses  [%s]  %s\n", e.UseCount, status, e.Description)
+		}
+		return nil
+	},
+}
+
+func init() {
+	statsCmd.Flags().IntVar(&statsTop, "top", 10, "Maximum number of entries to show (0 = all)")
+}
@@ -0,0 +1,114 @@
+// Package params parses and resolves the {"Label":"Default"} placeholders
+// embedded in stored commands, including the richer typed form
+// {"Label":"Default","type":"int|enum|secret|file|bool","choices":[...],
+// "pattern":"..."}. Both forms are valid JSON objects, so the richer schema
+// parses the original two-key placeholders unchanged: any key other than
+// the reserved type/choices/pattern is taken to be the variable's label.
+package params
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Spec describes one placeholder: its label, default value, and optional
+// validation/UI hints.
+type Spec struct {
+	Label   string
+	Default string
+	Type    string   // "", "int", "enum", "secret", "file", or "bool"
+	Choices []string // choices offered for type "enum"
+	Pattern string   // regexp the value must match; applies to "int" and the default text type
+}
+
+// decodePlaceholder attempts to decode a {...} placeholder starting at
+// the beginning of s (which must start with '{'). It uses a JSON decoder
+// rather than a regex so a brace embedded in a quoted value - e.g.
+// "pattern":"^\\d{3}$" - doesn't prematurely end the match: the decoder
+// tracks string literals correctly and only stops at the placeholder's
+// real closing brace. ok is false if s doesn't begin with a valid JSON
+// object, in which case the '{' should be treated as literal text.
+func decodePlaceholder(s string) (raw string, consumed int, ok bool) {
+	dec := json.NewDecoder(strings.NewReader(s))
+	var fields map[string]json.RawMessage
+	if err := dec.Decode(&fields); err != nil {
+		return "", 0, false
+	}
+	consumed = int(dec.InputOffset())
+	return s[:consumed], consumed, true
+}
+
+// parseSpec decodes one {...} placeholder into a Spec.
+func parseSpec(raw string) (Spec, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(raw), &fields); err != nil {
+		return Spec{}, fmt.Errorf("parsing placeholder %s: %w", raw, err)
+	}
+
+	var spec Spec
+	for key, value := range fields {
+		switch key {
+		case "type":
+			json.Unmarshal(value, &spec.Type)
+		case "choices":
+			json.Unmarshal(value, &spec.Choices)
+		case "pattern":
+			json.Unmarshal(value, &spec.Pattern)
+		default:
+			spec.Label = key
+			json.Unmarshal(value, &spec.Default)
+		}
+	}
+	return spec, nil
+}
+
+// Substitute walks cmdStr for placeholders and replaces each with a value:
+// first from overrides (keyed by Label, for non-interactive --param
+// scripting), otherwise by prompting interactively via Prompt. reader is
+// used for the default text/int prompts; the enum/secret/bool/file prompts
+// read the raw terminal directly.
+func Substitute(reader *bufio.Reader, cmdStr string, overrides map[string]string) (string, error) {
+	var b strings.Builder
+	for i := 0; i < len(cmdStr); {
+		brace := strings.IndexByte(cmdStr[i:], '{')
+		if brace == -1 {
+			b.WriteString(cmdStr[i:])
+			break
+		}
+		brace += i
+		b.WriteString(cmdStr[i:brace])
+
+		raw, consumed, ok := decodePlaceholder(cmdStr[brace:])
+		if !ok {
+			// Not a well-formed JSON object - ordinary shell brace syntax
+			// like ${HOME} or {a,b,c} - so leave it untouched rather than
+			// failing the whole substitution over it.
+			b.WriteByte('{')
+			i = brace + 1
+			continue
+		}
+
+		spec, err := parseSpec(raw)
+		if err != nil {
+			b.WriteString(raw)
+			i = brace + consumed
+			continue
+		}
+
+		if v, ok := overrides[spec.Label]; ok {
+			b.WriteString(v)
+			i = brace + consumed
+			continue
+		}
+
+		val, err := Prompt(reader, spec)
+		if err != nil {
+			return "", err
+		}
+		b.WriteString(val)
+		i = brace + consumed
+	}
+	return b.String(), nil
+}
@@ -0,0 +1,118 @@
+package params
+
+import (
+	"bufio"
+	"strings"
+	"testing"
+)
+
+func TestDecodePlaceholder(t *testing.T) {
+	tests := []struct {
+		name         string
+		in           string
+		wantOK       bool
+		wantConsumed int // 0 means "don't check, just that ok"
+	}{
+		{"two-key form", `{"Host":"localhost"} tail`, true, len(`{"Host":"localhost"}`)},
+		{"typed form", `{"Port":"22","type":"int"} tail`, true, len(`{"Port":"22","type":"int"}`)},
+		{"nested brace in pattern", `{"Code":"000","pattern":"^\\d{3}$"} tail`, true, 0},
+		{"shell var expansion", `{HOME}/bin`, false, 0},
+		{"brace list", `{a,b,c}`, false, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			raw, consumed, ok := decodePlaceholder(tt.in)
+			if ok != tt.wantOK {
+				t.Fatalf("decodePlaceholder(%q) ok = %v, want %v", tt.in, ok, tt.wantOK)
+			}
+			if !ok {
+				return
+			}
+			if tt.wantConsumed != 0 && consumed != tt.wantConsumed {
+				t.Errorf("consumed = %d, want %d", consumed, tt.wantConsumed)
+			}
+			if raw != tt.in[:consumed] {
+				t.Errorf("raw = %q, want %q", raw, tt.in[:consumed])
+			}
+		})
+	}
+}
+
+func TestParseSpec(t *testing.T) {
+	t.Run("two-key form", func(t *testing.T) {
+		spec, err := parseSpec(`{"Host":"localhost"}`)
+		if err != nil {
+			t.Fatalf("parseSpec: %v", err)
+		}
+		if spec.Label != "Host" || spec.Default != "localhost" || spec.Type != "" {
+			t.Errorf("got %+v", spec)
+		}
+	})
+
+	t.Run("typed form with choices and pattern", func(t *testing.T) {
+		spec, err := parseSpec(`{"Code":"000","type":"int","pattern":"^\\d{3}$","choices":["000","111"]}`)
+		if err != nil {
+			t.Fatalf("parseSpec: %v", err)
+		}
+		if spec.Label != "Code" || spec.Default != "000" || spec.Type != "int" {
+			t.Errorf("got %+v", spec)
+		}
+		if spec.Pattern != `^\d{3}$` {
+			t.Errorf("Pattern = %q", spec.Pattern)
+		}
+		if len(spec.Choices) != 2 || spec.Choices[0] != "000" || spec.Choices[1] != "111" {
+			t.Errorf("Choices = %v", spec.Choices)
+		}
+	})
+}
+
+func TestSubstitute(t *testing.T) {
+	tests := []struct {
+		name      string
+		cmdStr    string
+		overrides map[string]string
+		want      string
+	}{
+		{
+			name:      "two-key form resolved from override",
+			cmdStr:    `ssh {"Host":"localhost"}`,
+			overrides: map[string]string{"Host": "example.com"},
+			want:      "ssh example.com",
+		},
+		{
+			name:      "typed form resolved from override",
+			cmdStr:    `curl -m {"Timeout":"30","type":"int"} example.com`,
+			overrides: map[string]string{"Timeout": "60"},
+			want:      "curl -m 60 example.com",
+		},
+		{
+			name:      "shell brace syntax left untouched",
+			cmdStr:    `echo {a,b,c} ${HOME}`,
+			overrides: nil,
+			want:      `echo {a,b,c} ${HOME}`,
+		},
+		{
+			name:      "nested brace in pattern doesn't truncate the placeholder",
+			cmdStr:    `grep {"Code":"000","pattern":"^\\d{3}$"} file.txt`,
+			overrides: map[string]string{"Code": "123"},
+			want:      "grep 123 file.txt",
+		},
+		{
+			name:      "shell brace syntax mixed with a resolved placeholder",
+			cmdStr:    `echo {a,b,c} {"Name":"world"}`,
+			overrides: map[string]string{"Name": "there"},
+			want:      `echo {a,b,c} there`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Substitute(bufio.NewReader(strings.NewReader("")), tt.cmdStr, tt.overrides)
+			if err != nil {
+				t.Fatalf("Substitute: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Substitute(%q) = %q, want %q", tt.cmdStr, got, tt.want)
+			}
+		})
+	}
+}
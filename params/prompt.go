@@ -0,0 +1,282 @@
+package params
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// defaultIntPattern is used for type "int" placeholders that don't supply
+// their own pattern.
+const defaultIntPattern = `^-?\d+$`
+
+// Prompt asks the user for spec's value, using the UI appropriate to its
+// Type: arrow-key selection for "enum", a hidden prompt for "secret",
+// filesystem tab-completion for "file", a toggle for "bool", and a
+// validated text prompt (checked against Pattern) otherwise.
+func Prompt(reader *bufio.Reader, spec Spec) (string, error) {
+	switch spec.Type {
+	case "secret":
+		return promptSecret(spec)
+	case "enum":
+		return promptEnum(spec)
+	case "bool":
+		return promptBool(spec)
+	case "file":
+		return promptFile(spec)
+	case "int":
+		pattern := spec.Pattern
+		if pattern == "" {
+			pattern = defaultIntPattern
+		}
+		return promptValidated(reader, spec, pattern)
+	default:
+		return promptValidated(reader, spec, spec.Pattern)
+	}
+}
+
+// promptValidated reads a line of text, falling back to spec.Default on an
+// empty answer, and re-prompts until the answer matches pattern (an empty
+// pattern accepts anything). An empty answer with an empty default is
+// returned as is, without checking it against pattern.
+func promptValidated(reader *bufio.Reader, spec Spec, pattern string) (string, error) {
+	var re *regexp.Regexp
+	if pattern != "" {
+		compiled, err := regexp.Compile(pattern)
+		if err != nil {
+			return "", fmt.Errorf("%s: invalid pattern %q: %w", spec.Label, pattern, err)
+		}
+		re = compiled
+	}
+
+	for {
+		fmt.Printf("Input for '%s' [%s]: ", spec.Label, spec.Default)
+		val, _ := reader.ReadString('\n')
+		val = strings.TrimSpace(val)
+		if val == "" {
+			val = spec.Default
+		}
+
+		if re == nil || val == "" || re.MatchString(val) {
+			return val, nil
+		}
+		fmt.Printf("'%s' does not match required pattern %s, try again.\n", val, pattern)
+	}
+}
+
+// promptSecret hides the answer as it's typed, the same as a login prompt.
+func promptSecret(spec Spec) (string, error) {
+	fmt.Printf("Input for '%s' (hidden): ", spec.Label)
+	val, err := term.ReadPassword(int(os.Stdin.Fd()))
+	fmt.Println()
+	if err != nil {
+		return "", fmt.Errorf("reading secret: %w", err)
+	}
+	if len(val) == 0 {
+		return spec.Default, nil
+	}
+	return string(val), nil
+}
+
+// promptEnum lets the user cycle spec.Choices with the left/right arrow
+// keys and confirm with Enter.
+func promptEnum(spec Spec) (string, error) {
+	if len(spec.Choices) == 0 {
+		return "", fmt.Errorf("%s: type \"enum\" requires choices", spec.Label)
+	}
+
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		return spec.Default, nil
+	}
+	oldState, err := term.MakeRaw(fd)
+	if err != nil {
+		return "", fmt.Errorf("enabling raw mode: %w", err)
+	}
+	defer term.Restore(fd, oldState)
+
+	cursor := 0
+	for i, c := range spec.Choices {
+		if c == spec.Default {
+			cursor = i
+		}
+	}
+
+	draw := func() {
+		var b strings.Builder
+		fmt.Fprintf(&b, "\r\x1b[K%s: ", spec.Label)
+		for i, c := range spec.Choices {
+			if i == cursor {
+				fmt.Fprintf(&b, "[%s] ", c)
+			} else {
+				fmt.Fprintf(&b, "%s ", c)
+			}
+		}
+		os.Stdout.WriteString(b.String())
+	}
+	draw()
+
+	buf := make([]byte, 16)
+	for {
+		n, err := os.Stdin.Read(buf)
+		if err != nil || n == 0 {
+			return "", fmt.Errorf("reading input")
+		}
+		b := buf[:n]
+		switch {
+		case b[0] == '\r' || b[0] == '\n':
+			fmt.Println()
+			return spec.Choices[cursor], nil
+		case b[0] == 3: // Ctrl-C
+			fmt.Println()
+			return "", fmt.Errorf("prompt cancelled")
+		case len(b) >= 3 && b[0] == 27 && b[1] == '[':
+			switch b[2] {
+			case 'C', 'B':
+				if cursor < len(spec.Choices)-1 {
+					cursor++
+				}
+			case 'D', 'A':
+				if cursor > 0 {
+					cursor--
+				}
+			}
+			draw()
+		}
+	}
+}
+
+// promptBool shows spec.Default (or "false") and lets Space/Tab flip it
+// before Enter confirms.
+func promptBool(spec Spec) (string, error) {
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		return spec.Default, nil
+	}
+	oldState, err := term.MakeRaw(fd)
+	if err != nil {
+		return "", fmt.Errorf("enabling raw mode: %w", err)
+	}
+	defer term.Restore(fd, oldState)
+
+	val := spec.Default == "true"
+	draw := func() {
+		state := "false"
+		if val {
+			state = "true"
+		}
+		fmt.Printf("\r\x1b[K%s [space to toggle, enter to confirm]: %s", spec.Label, state)
+	}
+	draw()
+
+	buf := make([]byte, 8)
+	for {
+		n, err := os.Stdin.Read(buf)
+		if err != nil || n == 0 {
+			return "", fmt.Errorf("reading input")
+		}
+		switch buf[0] {
+		case '\r', '\n':
+			fmt.Println()
+			if val {
+				return "true", nil
+			}
+			return "false", nil
+		case ' ', '\t':
+			val = !val
+			draw()
+		case 3: // Ctrl-C
+			fmt.Println()
+			return "", fmt.Errorf("prompt cancelled")
+		}
+	}
+}
+
+// promptFile reads a path with Tab completing against the filesystem, the
+// same "extend to the longest common prefix" behavior as a shell.
+func promptFile(spec Spec) (string, error) {
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		return spec.Default, nil
+	}
+	oldState, err := term.MakeRaw(fd)
+	if err != nil {
+		return "", fmt.Errorf("enabling raw mode: %w", err)
+	}
+	defer term.Restore(fd, oldState)
+
+	val := ""
+	redraw := func() {
+		fmt.Printf("\r\x1b[K%s [%s]: %s", spec.Label, spec.Default, val)
+	}
+	redraw()
+
+	buf := make([]byte, 16)
+	for {
+		n, err := os.Stdin.Read(buf)
+		if err != nil || n == 0 {
+			return "", fmt.Errorf("reading input")
+		}
+		switch buf[0] {
+		case '\r', '\n':
+			fmt.Println()
+			if val == "" {
+				return spec.Default, nil
+			}
+			return val, nil
+		case '\t':
+			val = completePath(val)
+		case 127, 8: // Backspace
+			if len(val) > 0 {
+				val = val[:len(val)-1]
+			}
+		case 3: // Ctrl-C
+			fmt.Println()
+			return "", fmt.Errorf("prompt cancelled")
+		default:
+			for _, r := range string(buf[:n]) {
+				if r >= 32 && r < 127 {
+					val += string(r)
+				}
+			}
+		}
+		redraw()
+	}
+}
+
+// completePath extends partial to the longest prefix shared by every
+// filesystem entry matching partial*. It returns partial unchanged when
+// there's nothing to extend (no matches, or an ambiguous set that already
+// shares no further prefix).
+func completePath(partial string) string {
+	matches, err := filepath.Glob(partial + "*")
+	if err != nil || len(matches) == 0 {
+		return partial
+	}
+
+	common := matches[0]
+	for _, m := range matches[1:] {
+		common = commonPrefix(common, m)
+	}
+	if common == "" {
+		return partial
+	}
+	return common
+}
+
+func commonPrefix(a, b string) string {
+	n := len(a)
+	if len(b) < n {
+		n = len(b)
+	}
+	i := 0
+	for i < n && a[i] == b[i] {
+		i++
+	}
+	return a[:i]
+}
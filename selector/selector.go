@@ -0,0 +1,300 @@
+// Package selector implements an interactive fuzzy-finder style picker
+// for lcs entries, in the spirit of fzf: type to narrow matches, arrow keys
+// to move the cursor, and a preview pane showing the fully rendered command.
+package selector
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// Entry is the minimal data the selector needs to render and rank a
+// candidate. The main package's Entry carries extra bookkeeping fields
+// that the selector doesn't need to know about; Index is the one
+// exception, an opaque identifier the selector round-trips unchanged
+// (never used for ranking or display) so the caller can map a pick back
+// to its original entry without resorting to content equality.
+type Entry struct {
+	Description string
+	Command     string
+	Failed      bool // true if this entry's most recent execution had a non-zero exit code
+	Index       int  // opaque identifier supplied by the caller, returned unchanged by Select
+}
+
+// ErrCancelled is returned when the user aborts the picker (Esc or Ctrl-C)
+// without making a selection.
+var ErrCancelled = fmt.Errorf("selection cancelled")
+
+// Select draws an interactive fuzzy finder over entries and returns the one
+// the user picked. It requires stdin/stdout to be a TTY; callers should
+// check IsSupported() first and fall back to a simpler prompt otherwise.
+func Select(entries []Entry) (Entry, error) {
+	if len(entries) == 0 {
+		return Entry{}, fmt.Errorf("no entries to select from")
+	}
+
+	oldState, err := term.MakeRaw(int(os.Stdin.Fd()))
+	if err != nil {
+		return Entry{}, fmt.Errorf("enabling raw mode: %w", err)
+	}
+	defer term.Restore(int(os.Stdin.Fd()), oldState)
+
+	_, height, err := term.GetSize(int(os.Stdin.Fd()))
+	if err != nil || height <= 0 {
+		height = 24
+	}
+
+	s := &state{
+		entries: entries,
+		matches: rank(entries, ""),
+		maxRows: maxListRows(height),
+	}
+
+	buf := make([]byte, 16)
+	for {
+		s.draw()
+
+		n, err := os.Stdin.Read(buf)
+		if err != nil || n == 0 {
+			return Entry{}, ErrCancelled
+		}
+
+		switch done, selected, err := s.handle(buf[:n]); {
+		case err != nil:
+			clearDrawing(s.lastDrawnLines)
+			return Entry{}, err
+		case done:
+			clearDrawing(s.lastDrawnLines)
+			return selected, nil
+		}
+	}
+}
+
+// IsSupported reports whether the interactive selector can run in the
+// current environment (both stdin and stdout must be a real terminal).
+func IsSupported() bool {
+	return term.IsTerminal(int(os.Stdin.Fd())) && term.IsTerminal(int(os.Stdout.Fd()))
+}
+
+type state struct {
+	entries        []Entry
+	query          string
+	matches        []scored
+	cursor         int
+	maxRows        int
+	lastDrawnLines int
+}
+
+type scored struct {
+	entry Entry
+	score int
+}
+
+// handle consumes one chunk of raw terminal input and updates selector
+// state. It returns done=true once the user has made a selection, and
+// ErrCancelled if the user aborts.
+func (s *state) handle(b []byte) (done bool, selected Entry, err error) {
+	switch {
+	case b[0] == 3 || b[0] == 27 && len(b) == 1: // Ctrl-C or bare Esc
+		return false, Entry{}, ErrCancelled
+	case b[0] == '\r' || b[0] == '\n':
+		if len(s.matches) == 0 {
+			return false, Entry{}, nil
+		}
+		return true, s.matches[s.cursor].entry, nil
+	case b[0] == 127 || b[0] == 8: // Backspace
+		if len(s.query) > 0 {
+			s.query = s.query[:len(s.query)-1]
+			s.refresh()
+		}
+		return false, Entry{}, nil
+	case len(b) >= 3 && b[0] == 27 && b[1] == '[':
+		switch b[2] {
+		case 'A': // Up
+			if s.cursor > 0 {
+				s.cursor--
+			}
+		case 'B': // Down
+			if s.cursor < len(s.matches)-1 {
+				s.cursor++
+			}
+		}
+		return false, Entry{}, nil
+	default:
+		for _, r := range string(b) {
+			if r >= 32 && r < 127 {
+				s.query += string(r)
+			}
+		}
+		s.refresh()
+		return false, Entry{}, nil
+	}
+}
+
+func (s *state) refresh() {
+	s.matches = rank(s.entries, s.query)
+	s.cursor = 0
+}
+
+// draw repaints the prompt line, the match list, and the preview pane,
+// first clearing whatever was drawn on the previous frame.
+func (s *state) draw() {
+	clearDrawing(s.lastDrawnLines)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "\r\x1b[K> %s\n", s.query)
+	lines := 1
+
+	visible := s.matches
+	if len(visible) > s.maxRows {
+		visible = visible[:s.maxRows]
+	}
+
+	for i, m := range visible {
+		prefix := "  "
+		if i == s.cursor {
+			prefix = "> "
+		}
+		desc := m.entry.Description
+		if m.entry.Failed {
+			desc = "\x1b[31m" + desc + "\x1b[0m"
+		}
+		fmt.Fprintf(&b, "\r\x1b[K%s%s\n", prefix, desc)
+		lines++
+	}
+
+	fmt.Fprintf(&b, "\r\x1b[K\n")
+	lines++
+	if len(s.matches) > 0 {
+		fmt.Fprintf(&b, "\r\x1b[KCmd: %s\n", highlightPlaceholders(s.matches[s.cursor].entry.Command))
+	} else {
+		fmt.Fprintf(&b, "\r\x1b[KNo matches\n")
+	}
+	lines++
+
+	fmt.Fprintf(&b, "\x1b[%dA", lines)
+
+	os.Stdout.WriteString(b.String())
+	s.lastDrawnLines = lines
+}
+
+func clearDrawing(lines int) {
+	if lines <= 0 {
+		return
+	}
+	for i := 0; i < lines; i++ {
+		os.Stdout.WriteString("\x1b[K\n")
+	}
+	fmt.Fprintf(os.Stdout, "\x1b[%dA", lines)
+}
+
+// highlightPlaceholders wraps `{"Label":"Default"}` style variables in
+// reverse-video so they stand out in the preview pane. A placeholder is
+// decoded as JSON rather than matched with a regex, so a brace embedded
+// in a quoted value - e.g. "pattern":"^\\d{3}$" - doesn't prematurely end
+// the span; a '{' that isn't valid JSON (${HOME}, {a,b,c}) is left as is.
+func highlightPlaceholders(cmd string) string {
+	var b strings.Builder
+	for i := 0; i < len(cmd); {
+		brace := strings.IndexByte(cmd[i:], '{')
+		if brace == -1 {
+			b.WriteString(cmd[i:])
+			break
+		}
+		brace += i
+		b.WriteString(cmd[i:brace])
+
+		dec := json.NewDecoder(strings.NewReader(cmd[brace:]))
+		var fields map[string]json.RawMessage
+		if err := dec.Decode(&fields); err != nil {
+			b.WriteByte('{')
+			i = brace + 1
+			continue
+		}
+
+		end := brace + int(dec.InputOffset())
+		b.WriteString("\x1b[7m" + cmd[brace:end] + "\x1b[0m")
+		i = end
+	}
+	return b.String()
+}
+
+// maxListRows caps the visible match list so the prompt, list, and preview
+// pane always fit within the terminal height.
+func maxListRows(termHeight int) int {
+	rows := termHeight - 4
+	if rows < 3 {
+		rows = 3
+	}
+	return rows
+}
+
+// rank scores every entry against query and returns matches sorted with
+// the best score first. An empty query matches everything, preserving the
+// original order.
+func rank(entries []Entry, query string) []scored {
+	results := make([]scored, 0, len(entries))
+	q := strings.ToLower(query)
+
+	for _, e := range entries {
+		if q == "" {
+			results = append(results, scored{entry: e, score: 0})
+			continue
+		}
+		score, ok := fuzzyScore(strings.ToLower(e.Description), q)
+		if cmdScore, cmdOk := fuzzyScore(strings.ToLower(e.Command), q); cmdOk && (!ok || cmdScore > score) {
+			score, ok = cmdScore, true
+		}
+		if ok {
+			results = append(results, scored{entry: e, score: score})
+		}
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].score > results[j].score
+	})
+	return results
+}
+
+// fuzzyScore reports whether every rune of query appears in text in order,
+// and a score that rewards contiguous runs and early matches (same spirit
+// as fzf's scoring: consecutive characters and matches near the start of
+// the string rank higher than scattered ones).
+func fuzzyScore(text, query string) (int, bool) {
+	if query == "" {
+		return 0, true
+	}
+	if strings.Contains(text, query) {
+		// Exact substring match is the strongest signal; favor earlier
+		// occurrences.
+		idx := strings.Index(text, query)
+		return 1000 - idx, true
+	}
+
+	score := 0
+	ti := 0
+	consecutive := 0
+	for _, qc := range query {
+		found := false
+		for ; ti < len(text); ti++ {
+			if rune(text[ti]) == qc {
+				score += 10
+				score += consecutive
+				consecutive++
+				ti++
+				found = true
+				break
+			}
+			consecutive = 0
+		}
+		if !found {
+			return 0, false
+		}
+	}
+	return score, true
+}
@@ -0,0 +1,180 @@
+package store
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"filippo.io/age"
+)
+
+// ageStore wraps the original CSV format in an age-encrypted file, so e.g.
+// ~/.lcs-db.age can be safely committed to a dotfiles repo. Every call
+// decrypts the whole file to an in-memory buffer and re-parses it with the
+// CSV format, the same read-the-whole-file-every-time approach csvStore
+// uses; writes re-encrypt and replace the file atomically via os.Rename.
+//
+// The passphrase itself is resolved by the caller (cmd prompts for it, or
+// gets it from the agent package's cache) - ageStore just uses whatever
+// it's given.
+type ageStore struct {
+	path       string
+	passphrase string
+}
+
+func newAgeStore(path, passphrase string) *ageStore {
+	return &ageStore{path: path, passphrase: passphrase}
+}
+
+// OpenEncrypted opens the age-encrypted store at path using passphrase.
+// Unlike Open, this always returns an age-backed Store regardless of
+// path's extension, since the caller has already resolved the passphrase
+// needed to read it.
+func OpenEncrypted(path, passphrase string) (Store, error) {
+	return newAgeStore(path, passphrase), nil
+}
+
+func (s *ageStore) List() ([]Entry, error) {
+	plaintext, err := s.decrypt()
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return parseCSV(plaintext), nil
+}
+
+func (s *ageStore) Add(e Entry) error {
+	entries, err := s.List()
+	if err != nil {
+		return err
+	}
+	entries = append(entries, e)
+	return s.rewrite(entries)
+}
+
+func (s *ageStore) Update(index int, e Entry) error {
+	entries, err := s.List()
+	if err != nil {
+		return err
+	}
+	if index < 0 || index >= len(entries) {
+		return os.ErrInvalid
+	}
+	entries[index] = e
+	return s.rewrite(entries)
+}
+
+func (s *ageStore) Delete(index int) error {
+	entries, err := s.List()
+	if err != nil {
+		return err
+	}
+	if index < 0 || index >= len(entries) {
+		return os.ErrInvalid
+	}
+	entries = append(entries[:index], entries[index+1:]...)
+	return s.rewrite(entries)
+}
+
+func (s *ageStore) Close() error { return nil }
+
+// decrypt reads and decrypts the whole file with the scrypt (passphrase)
+// age recipient.
+func (s *ageStore) decrypt() ([]byte, error) {
+	f, err := os.Open(s.path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	identity, err := age.NewScryptIdentity(s.passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("preparing passphrase: %w", err)
+	}
+
+	r, err := age.Decrypt(f, identity)
+	if err != nil {
+		return nil, fmt.Errorf("decrypting %s (wrong passphrase?): %w", s.path, err)
+	}
+	return io.ReadAll(r)
+}
+
+// rewrite encodes entries as CSV, encrypts it, and atomically replaces the
+// DB file so a crash mid-write can never leave it corrupt.
+func (s *ageStore) rewrite(entries []Entry) error {
+	var plaintext bytes.Buffer
+	writer := csv.NewWriter(&plaintext)
+	writer.Comma = ';'
+	for _, e := range entries {
+		if err := writer.Write([]string{e.Description, e.Command}); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return err
+	}
+
+	recipient, err := age.NewScryptRecipient(s.passphrase)
+	if err != nil {
+		return fmt.Errorf("preparing passphrase: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), ".lcs-db-*.age.tmp")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmp.Name()
+	defer os.Remove(tmpPath) // no-op once the rename below succeeds
+
+	w, err := age.Encrypt(tmp, recipient)
+	if err != nil {
+		tmp.Close()
+		return fmt.Errorf("encrypting %s: %w", s.path, err)
+	}
+	if _, err := w.Write(plaintext.Bytes()); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := w.Close(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmpPath, s.path)
+}
+
+// parseCSV is the same ; separated, #-commented, lazy-quoted format
+// csvStore.List reads from disk, applied here to an in-memory buffer
+// instead. Malformed lines are skipped rather than aborting the read.
+func parseCSV(data []byte) []Entry {
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.Comma = ';'
+	reader.FieldsPerRecord = -1
+	reader.Comment = '#'
+	reader.LazyQuotes = true
+
+	var entries []Entry
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			continue
+		}
+		if len(record) < 2 {
+			continue
+		}
+		entries = append(entries, Entry{Description: record[0], Command: record[1]})
+	}
+	return entries
+}
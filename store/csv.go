@@ -0,0 +1,109 @@
+package store
+
+import (
+	"encoding/csv"
+	"io"
+	"os"
+)
+
+// csvStore is the original ; separated CSV backend. It has no open handle
+// of its own: every call reads or rewrites the whole file, matching how
+// lcs has always treated the DB.
+type csvStore struct {
+	path string
+}
+
+func newCSVStore(path string) *csvStore {
+	return &csvStore{path: path}
+}
+
+func (s *csvStore) List() ([]Entry, error) {
+	file, err := os.Open(s.path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.Comma = ';'
+	reader.FieldsPerRecord = -1
+	reader.Comment = '#'     // Treat lines starting with # as comments
+	reader.LazyQuotes = true // Allow quotes to appear in non-quoted fields
+
+	var entries []Entry
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			// Skip malformed lines rather than aborting the whole read.
+			continue
+		}
+		if len(record) < 2 {
+			continue
+		}
+		entries = append(entries, Entry{Description: record[0], Command: record[1]})
+	}
+	return entries, nil
+}
+
+func (s *csvStore) Add(e Entry) error {
+	f, err := os.OpenFile(s.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	writer := csv.NewWriter(f)
+	writer.Comma = ';'
+	if err := writer.Write([]string{e.Description, e.Command}); err != nil {
+		return err
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+func (s *csvStore) Update(index int, e Entry) error {
+	entries, err := s.List()
+	if err != nil {
+		return err
+	}
+	if index < 0 || index >= len(entries) {
+		return os.ErrInvalid
+	}
+	entries[index] = e
+	return s.rewrite(entries)
+}
+
+func (s *csvStore) Delete(index int) error {
+	entries, err := s.List()
+	if err != nil {
+		return err
+	}
+	if index < 0 || index >= len(entries) {
+		return os.ErrInvalid
+	}
+	entries = append(entries[:index], entries[index+1:]...)
+	return s.rewrite(entries)
+}
+
+func (s *csvStore) rewrite(entries []Entry) error {
+	f, err := os.OpenFile(s.path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	writer := csv.NewWriter(f)
+	writer.Comma = ';'
+	for _, e := range entries {
+		if err := writer.Write([]string{e.Description, e.Command}); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+func (s *csvStore) Close() error { return nil }
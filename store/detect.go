@@ -0,0 +1,46 @@
+package store
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// Open opens the store backend appropriate for path. kind, when non-empty,
+// forces a specific backend ("csv", "json", or "sqlite") regardless of the
+// file extension. Age-encrypted stores need a passphrase and so aren't
+// opened here; use KindFromExt to detect them and OpenEncrypted to open
+// them.
+func Open(path string, kind string) (Store, error) {
+	if kind == "" {
+		kind = KindFromExt(path)
+	}
+
+	switch strings.ToLower(kind) {
+	case "csv":
+		return newCSVStore(path), nil
+	case "json":
+		return newJSONStore(path), nil
+	case "sqlite", "db":
+		return newSQLiteStore(path)
+	case "age":
+		return nil, fmt.Errorf("%s is age-encrypted; use OpenEncrypted with a passphrase", path)
+	default:
+		return nil, fmt.Errorf("unknown store backend %q (expected csv, json, or sqlite)", kind)
+	}
+}
+
+// KindFromExt guesses a store backend from path's file extension,
+// defaulting to csv when nothing more specific matches.
+func KindFromExt(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return "json"
+	case ".db", ".sqlite", ".sqlite3":
+		return "sqlite"
+	case ".age":
+		return "age"
+	default:
+		return "csv"
+	}
+}
@@ -0,0 +1,26 @@
+package store
+
+import "time"
+
+// Execution is a single recorded run of an entry's command.
+type Execution struct {
+	Command   string // the fully expanded command that was run
+	ExitCode  int
+	Timestamp time.Time
+	Dir       string // working directory lcs was invoked from
+}
+
+// HistoryStore is implemented by backends that can record and query
+// per-command execution history. Only the SQLite backend currently
+// supports it; CSV and JSON stores have nowhere to put this without
+// changing their on-disk format.
+type HistoryStore interface {
+	// RecordExecution logs exec against the entry at index (as returned
+	// by the most recent List call) and bumps that entry's use_count,
+	// last_used_at, and exit_code.
+	RecordExecution(index int, exec Execution) error
+
+	// History returns the most recent executions across all entries,
+	// newest first, capped at limit (0 means no limit).
+	History(limit int) ([]Execution, error)
+}
@@ -0,0 +1,84 @@
+package store
+
+import (
+	"encoding/json"
+	"os"
+)
+
+// jsonStore stores the library as a JSON array of Entry objects. Like
+// csvStore, every call reads or rewrites the whole file.
+type jsonStore struct {
+	path string
+}
+
+func newJSONStore(path string) *jsonStore {
+	return &jsonStore{path: path}
+}
+
+func (s *jsonStore) List() ([]Entry, error) {
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, nil
+	}
+	var entries []Entry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+func (s *jsonStore) Add(e Entry) error {
+	entries, err := s.listOrEmpty()
+	if err != nil {
+		return err
+	}
+	entries = append(entries, e)
+	return s.rewrite(entries)
+}
+
+func (s *jsonStore) Update(index int, e Entry) error {
+	entries, err := s.List()
+	if err != nil {
+		return err
+	}
+	if index < 0 || index >= len(entries) {
+		return os.ErrInvalid
+	}
+	entries[index] = e
+	return s.rewrite(entries)
+}
+
+func (s *jsonStore) Delete(index int) error {
+	entries, err := s.List()
+	if err != nil {
+		return err
+	}
+	if index < 0 || index >= len(entries) {
+		return os.ErrInvalid
+	}
+	entries = append(entries[:index], entries[index+1:]...)
+	return s.rewrite(entries)
+}
+
+func (s *jsonStore) Close() error { return nil }
+
+// listOrEmpty treats a missing file as an empty library, so Add can create
+// the DB on first use the same way the CSV backend does.
+func (s *jsonStore) listOrEmpty() ([]Entry, error) {
+	entries, err := s.List()
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	return entries, err
+}
+
+func (s *jsonStore) rewrite(entries []Entry) error {
+	data, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(s.path, data, 0644)
+}
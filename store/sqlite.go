@@ -0,0 +1,180 @@
+package store
+
+import (
+	"database/sql"
+	"fmt"
+
+	_ "modernc.org/sqlite" // pure-Go sqlite driver, no cgo required
+)
+
+const sqliteSchema = `
+CREATE TABLE IF NOT EXISTS entries (
+	id           INTEGER PRIMARY KEY AUTOINCREMENT,
+	description  TEXT NOT NULL,
+	command      TEXT NOT NULL,
+	created_at   INTEGER NOT NULL DEFAULT 0,
+	last_used_at INTEGER NOT NULL DEFAULT 0,
+	use_count    INTEGER NOT NULL DEFAULT 0,
+	exit_code    INTEGER NOT NULL DEFAULT 0
+);
+CREATE TABLE IF NOT EXISTS executions (
+	id        INTEGER PRIMARY KEY AUTOINCREMENT,
+	entry_id  INTEGER NOT NULL,
+	command   TEXT NOT NULL,
+	exit_code INTEGER NOT NULL,
+	timestamp INTEGER NOT NULL,
+	dir       TEXT NOT NULL
+);`
+
+// sqliteStore is the SQLite backend. Beyond description/command it tracks
+// created_at, last_used_at, use_count, and exit_code so future features
+// (frecency ranking, `lcs stats`) have somewhere to read history from.
+//
+// Store.Update/Delete address entries by their position in the slice last
+// returned by List, so sqliteStore remembers the row ids from that call
+// and translates index -> id before issuing SQL.
+type sqliteStore struct {
+	db  *sql.DB
+	ids []int64
+}
+
+func newSQLiteStore(path string) (*sqliteStore, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := db.Exec(sqliteSchema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("creating schema: %w", err)
+	}
+	return &sqliteStore{db: db}, nil
+}
+
+func (s *sqliteStore) List() ([]Entry, error) {
+	rows, err := s.db.Query(`SELECT id, description, command, created_at, last_used_at, use_count, exit_code FROM entries ORDER BY id ASC`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []Entry
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		var e Entry
+		var createdAt, lastUsedAt int64
+		if err := rows.Scan(&id, &e.Description, &e.Command, &createdAt, &lastUsedAt, &e.UseCount, &e.ExitCode); err != nil {
+			return nil, err
+		}
+		e.CreatedAt = epochToTime(createdAt)
+		e.LastUsedAt = epochToTime(lastUsedAt)
+		entries = append(entries, e)
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	s.ids = ids
+	return entries, nil
+}
+
+func (s *sqliteStore) Add(e Entry) error {
+	_, err := s.db.Exec(
+		`INSERT INTO entries (description, command, created_at, last_used_at, use_count, exit_code) VALUES (?, ?, ?, ?, ?, ?)`,
+		e.Description, e.Command, timeToEpoch(e.CreatedAt), timeToEpoch(e.LastUsedAt), e.UseCount, e.ExitCode,
+	)
+	return err
+}
+
+func (s *sqliteStore) Update(index int, e Entry) error {
+	id, err := s.idForIndex(index)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.Exec(
+		`UPDATE entries SET description = ?, command = ?, last_used_at = ?, use_count = ?, exit_code = ? WHERE id = ?`,
+		e.Description, e.Command, timeToEpoch(e.LastUsedAt), e.UseCount, e.ExitCode, id,
+	)
+	return err
+}
+
+func (s *sqliteStore) Delete(index int) error {
+	id, err := s.idForIndex(index)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.Exec(`DELETE FROM entries WHERE id = ?`, id)
+	return err
+}
+
+func (s *sqliteStore) Close() error {
+	return s.db.Close()
+}
+
+// RecordExecution implements HistoryStore. It logs the execution and bumps
+// the entry's use_count, last_used_at, and exit_code so List (and frecency
+// ranking built on it) see the result immediately.
+func (s *sqliteStore) RecordExecution(index int, exec Execution) error {
+	id, err := s.idForIndex(index)
+	if err != nil {
+		return err
+	}
+
+	if _, err := s.db.Exec(
+		`INSERT INTO executions (entry_id, command, exit_code, timestamp, dir) VALUES (?, ?, ?, ?, ?)`,
+		id, exec.Command, exec.ExitCode, timeToEpoch(exec.Timestamp), exec.Dir,
+	); err != nil {
+		return fmt.Errorf("recording execution: %w", err)
+	}
+
+	_, err = s.db.Exec(
+		`UPDATE entries SET use_count = use_count + 1, last_used_at = ?, exit_code = ? WHERE id = ?`,
+		timeToEpoch(exec.Timestamp), exec.ExitCode, id,
+	)
+	return err
+}
+
+// History implements HistoryStore, returning the most recent executions
+// across all entries, newest first.
+func (s *sqliteStore) History(limit int) ([]Execution, error) {
+	query := `SELECT command, exit_code, timestamp, dir FROM executions ORDER BY timestamp DESC`
+	args := []any{}
+	if limit > 0 {
+		query += ` LIMIT ?`
+		args = append(args, limit)
+	}
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var execs []Execution
+	for rows.Next() {
+		var e Execution
+		var ts int64
+		if err := rows.Scan(&e.Command, &e.ExitCode, &ts, &e.Dir); err != nil {
+			return nil, err
+		}
+		e.Timestamp = epochToTime(ts)
+		execs = append(execs, e)
+	}
+	return execs, rows.Err()
+}
+
+// idForIndex resolves a List() position to a row id, re-listing first if
+// index falls outside the ids cached by a prior List call (or none are
+// cached). An in-range index is resolved from the cache as is.
+func (s *sqliteStore) idForIndex(index int) (int64, error) {
+	if index < 0 || index >= len(s.ids) {
+		if _, err := s.List(); err != nil {
+			return 0, err
+		}
+	}
+	if index < 0 || index >= len(s.ids) {
+		return 0, fmt.Errorf("index %d out of range", index)
+	}
+	return s.ids[index], nil
+}
@@ -0,0 +1,31 @@
+// Package store provides pluggable backends for the lcs command library:
+// a plain ; separated CSV file (the original format), a JSON file, and a
+// SQLite database. The backend is chosen by Open based on the DB file's
+// extension, or forced via an explicit kind.
+package store
+
+import "time"
+
+// Entry represents a single command in the library. CreatedAt, LastUsedAt,
+// UseCount, and ExitCode are only tracked by backends that support
+// execution history (currently SQLite); CSV and JSON entries leave them
+// zero.
+type Entry struct {
+	Description string
+	Command     string
+	CreatedAt   time.Time
+	LastUsedAt  time.Time
+	UseCount    int
+	ExitCode    int
+}
+
+// Store is the backend-agnostic interface lcs uses to read and write its
+// command library. Entries are addressed by their position in the slice
+// last returned by List.
+type Store interface {
+	List() ([]Entry, error)
+	Add(Entry) error
+	Update(int, Entry) error
+	Delete(int) error
+	Close() error
+}
@@ -0,0 +1,21 @@
+package store
+
+import "time"
+
+// The SQLite schema stores timestamps as epoch seconds (see the hs9001
+// history schema this was modeled on), not SQLite's native datetime text,
+// so frecency math downstream is plain integer arithmetic.
+
+func timeToEpoch(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.Unix()
+}
+
+func epochToTime(epoch int64) time.Time {
+	if epoch == 0 {
+		return time.Time{}
+	}
+	return time.Unix(epoch, 0)
+}